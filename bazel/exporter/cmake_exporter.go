@@ -0,0 +1,226 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces"
+)
+
+// CMakeLibraryExportDesc describes a single CMake library target to
+// generate and the Bazel `cc_library` (or filegroup) targets whose files
+// make it up.
+type CMakeLibraryExportDesc struct {
+	// Name is the CMake target name, e.g. "skia_core".
+	Name string
+	// Rules is the set of fully-qualified Bazel targets whose srcs become
+	// Name's sources. Any rule among them that's a cc_library or
+	// skia_cc_library also contributes its own "includes" and "defines"
+	// attributes to Name's target_include_directories and
+	// target_compile_definitions, in addition to IncludeDirs and Defines
+	// below.
+	Rules []string
+	// IncludeDirs are workspace-relative directories added to Name's
+	// public include path, e.g. "include", beyond whatever the queried
+	// cc_library rules in Rules already declare via "includes".
+	IncludeDirs []string
+	// Defines are preprocessor defines added to Name's public compile
+	// definitions, e.g. "SK_GANESH", beyond whatever the queried
+	// cc_library rules in Rules already declare via "defines".
+	Defines []string
+}
+
+// CMakeExportDesc describes all the libraries the exporter should
+// maintain inside a single CMakeLists.txt.
+type CMakeExportDesc struct {
+	// CMakeLists is the path of the CMakeLists.txt file, relative to the
+	// workspace root.
+	CMakeLists string
+	// Libraries are the libraries to declare inside CMakeLists.
+	Libraries []CMakeLibraryExportDesc
+}
+
+// CMakeExporterParams configures a CMakeExporter.
+type CMakeExporterParams struct {
+	// WorkspaceDir is the absolute path to the root of the Bazel workspace.
+	WorkspaceDir string
+	// ExportDescs describes every CMakeLists.txt the exporter maintains.
+	ExportDescs []CMakeExportDesc
+}
+
+// CMakeExporter regenerates the CMakeLists.txt files consumed by CMake
+// from the output of a `bazel cquery`, for downstream Skia integrators
+// that don't build with GN.
+type CMakeExporter struct {
+	params CMakeExporterParams
+	fs     interfaces.FileSystem
+}
+
+// NewCMakeExporter returns a CMakeExporter configured to maintain the
+// CMakeLists.txt files described by params.
+func NewCMakeExporter(params CMakeExporterParams, fs interfaces.FileSystem) *CMakeExporter {
+	return &CMakeExporter{params: params, fs: fs}
+}
+
+// workspaceToAbsPath converts a path relative to the workspace root into an
+// absolute path.
+func (e *CMakeExporter) workspaceToAbsPath(p string) string {
+	return filepath.Join(e.params.WorkspaceDir, p)
+}
+
+// Export regenerates every CMakeLists.txt described by
+// e.params.ExportDescs from the targets reported by qcmd.
+func (e *CMakeExporter) Export(qcmd interfaces.QueryCommand) error {
+	rules, err := e.queryRules(qcmd)
+	if err != nil {
+		return fmt.Errorf("querying rules: %w", err)
+	}
+
+	for _, desc := range e.params.ExportDescs {
+		contents, err := e.buildCMakeLists(desc, rules)
+		if err != nil {
+			return fmt.Errorf("building %s: %w", desc.CMakeLists, err)
+		}
+		w, err := e.fs.OpenFile(e.workspaceToAbsPath(desc.CMakeLists))
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", desc.CMakeLists, err)
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			return fmt.Errorf("writing %s: %w", desc.CMakeLists, err)
+		}
+	}
+	return nil
+}
+
+// buildCMakeLists computes the full text of a single CMakeLists.txt given
+// the already-resolved Bazel rules.
+func (e *CMakeExporter) buildCMakeLists(desc CMakeExportDesc, rules map[string]*build.Rule) (string, error) {
+	handlers := newRuleHandlers(rules)
+	var sb strings.Builder
+	sb.WriteString("# DO NOT EDIT: This is a generated file.\n")
+	sb.WriteString("# See //bazel/exporter_tool/README.md for more information.\n")
+
+	for _, lib := range desc.Libraries {
+		srcs, err := e.cmakeSourcesFor(lib, rules, handlers)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "add_library(%s)\n", lib.Name)
+
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "target_sources(%s\n    PRIVATE\n", lib.Name)
+		for _, s := range srcs {
+			fmt.Fprintf(&sb, "        %s\n", s)
+		}
+		sb.WriteString(")\n")
+
+		includeDirs, defines := cmakeAttributesFor(lib, rules)
+
+		if len(includeDirs) > 0 {
+			sb.WriteString("\n")
+			fmt.Fprintf(&sb, "target_include_directories(%s\n    PUBLIC\n", lib.Name)
+			for _, d := range includeDirs {
+				fmt.Fprintf(&sb, "        %s\n", d)
+			}
+			sb.WriteString(")\n")
+		}
+
+		if len(defines) > 0 {
+			sb.WriteString("\n")
+			fmt.Fprintf(&sb, "target_compile_definitions(%s\n    PUBLIC\n", lib.Name)
+			for _, d := range defines {
+				fmt.Fprintf(&sb, "        %s\n", d)
+			}
+			sb.WriteString(")\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// cmakeSourcesFor resolves lib.Rules into the sorted, deduplicated,
+// workspace-relative source paths that belong in lib's target_sources.
+func (e *CMakeExporter) cmakeSourcesFor(lib CMakeLibraryExportDesc, rules map[string]*build.Rule, handlers map[string]RuleHandler) ([]string, error) {
+	var targets []string
+	for _, ruleLabel := range lib.Rules {
+		srcs, err := collectRuleTargets(rules, handlers, ruleLabel)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", lib.Name, err)
+		}
+		targets = append(targets, srcs...)
+	}
+	paths, err := convertTargetsToFilePaths(targets)
+	if err != nil {
+		return nil, fmt.Errorf("converting targets for %s: %w", lib.Name, err)
+	}
+	paths = filterDeprecatedFiles(paths)
+	if dup, found := findDuplicate(paths); found {
+		return nil, fmt.Errorf("%s: duplicate file %q", lib.Name, dup)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// cmakeAttributesFor returns the sorted, deduplicated include directories
+// and preprocessor defines for lib: lib.IncludeDirs and lib.Defines,
+// together with the "includes" and "defines" attributes of every rule in
+// lib.Rules that's a cc_library or skia_cc_library (rules reached only
+// through an alias aren't followed, since an alias carries no attributes
+// of its own to read).
+func cmakeAttributesFor(lib CMakeLibraryExportDesc, rules map[string]*build.Rule) (includeDirs, defines []string) {
+	includeDirs = append(includeDirs, lib.IncludeDirs...)
+	defines = append(defines, lib.Defines...)
+	for _, ruleLabel := range lib.Rules {
+		rule, ok := rules[ruleLabel]
+		if !ok {
+			continue
+		}
+		switch rule.GetRuleClass() {
+		case "cc_library", "skia_cc_library":
+			includeDirs = append(includeDirs, attrStringList(rule, "includes")...)
+			defines = append(defines, attrStringList(rule, "defines")...)
+		}
+	}
+	return sortedUnique(includeDirs), sortedUnique(defines)
+}
+
+// sortedUnique returns items deduplicated and sorted lexically.
+func sortedUnique(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if !seen[it] {
+			seen[it] = true
+			out = append(out, it)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// queryExpression builds the `bazel cquery` expression covering every rule
+// referenced by e.params.ExportDescs.
+func (e *CMakeExporter) queryExpression() string {
+	var labels []string
+	for _, desc := range e.params.ExportDescs {
+		for _, lib := range desc.Libraries {
+			labels = append(labels, lib.Rules...)
+		}
+	}
+	return queryExpressionForLabels(labels)
+}
+
+// queryRules runs qcmd and indexes every RULE target in the result by its
+// fully-qualified label.
+func (e *CMakeExporter) queryRules(qcmd interfaces.QueryCommand) (map[string]*build.Rule, error) {
+	return queryRulesForExpression(qcmd, e.queryExpression())
+}
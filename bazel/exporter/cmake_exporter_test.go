@@ -0,0 +1,161 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces/mocks"
+	"google.golang.org/protobuf/proto"
+)
+
+// The expected CMakeLists.txt contents for createCoreLibraryQueryResult().
+// This expected result is handmade.
+const coreLibraryExpectedCMakeLists = `# DO NOT EDIT: This is a generated file.
+# See //bazel/exporter_tool/README.md for more information.
+
+add_library(skia_core)
+
+target_sources(skia_core
+    PRIVATE
+        src/core/SkAAClip.cpp
+        src/core/SkATrace.cpp
+        src/core/SkAlphaRuns.cpp
+)
+
+target_include_directories(skia_core
+    PUBLIC
+        include
+)
+
+target_compile_definitions(skia_core
+    PUBLIC
+        SK_GANESH
+)
+`
+
+var cmakeExportDescs = []CMakeExportDesc{
+	{CMakeLists: "CMakeLists.txt", Libraries: []CMakeLibraryExportDesc{
+		{
+			Name:        "skia_core",
+			Rules:       []string{"//src/core:core_srcs"},
+			IncludeDirs: []string{"include"},
+			Defines:     []string{"SK_GANESH"},
+		},
+	}},
+}
+
+var testCMakeExporterParams = CMakeExporterParams{
+	WorkspaceDir: "/path/to/workspace",
+	ExportDescs:  cmakeExportDescs,
+}
+
+func createCoreLibraryQueryResult() *build.QueryResult {
+	qr := build.QueryResult{}
+	ruleDesc := build.Target_RULE
+
+	srcs := []string{
+		"//src/core:SkAAClip.cpp",
+		"//src/core:SkATrace.cpp",
+		"//src/core:SkAlphaRuns.cpp",
+	}
+	r := createTestBuildRule("//src/core:core_srcs", "filegroup",
+		"/path/to/workspace/src/core/BUILD.bazel:376:20", srcs)
+	t1 := build.Target{Rule: r, Type: &ruleDesc}
+	qr.Target = append(qr.Target, &t1)
+	return &qr
+}
+
+func TestCMakeExporterExport_ValidInput_Success(t *testing.T) {
+	qr := createCoreLibraryQueryResult()
+	protoData, err := proto.Marshal(qr)
+	require.NoError(t, err)
+
+	fs := mocks.NewFileSystem(t)
+	var contents bytes.Buffer
+	fs.On("OpenFile", mock.Anything).Once().Run(func(args mock.Arguments) {
+		path := args.String(0)
+		assert.True(t, filepath.IsAbs(path))
+		assert.Equal(t, "/path/to/workspace/CMakeLists.txt", filepath.ToSlash(path))
+	}).Return(&contents, nil).Once()
+	e := NewCMakeExporter(testCMakeExporterParams, fs)
+	qcmd := mocks.NewQueryCommand(t)
+	qcmd.On("Read", mock.Anything).Return(protoData, nil).Once()
+	err = e.Export(qcmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, coreLibraryExpectedCMakeLists, contents.String())
+}
+
+func TestCMakeExporterExport_UnknownRule_ReturnsSuggestionError(t *testing.T) {
+	qr := createCoreLibraryQueryResult()
+	protoData, err := proto.Marshal(qr)
+	require.NoError(t, err)
+
+	badParams := CMakeExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []CMakeExportDesc{
+			{CMakeLists: "CMakeLists.txt", Libraries: []CMakeLibraryExportDesc{
+				{Name: "skia_core", Rules: []string{"//src/core:core_srcz"}},
+			}},
+		},
+	}
+	fs := mocks.NewFileSystem(t)
+	e := NewCMakeExporter(badParams, fs)
+	qcmd := mocks.NewQueryCommand(t)
+	qcmd.On("Read", mock.Anything).Return(protoData, nil).Once()
+	err = e.Export(qcmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean")
+	assert.Contains(t, err.Error(), "//src/core:core_srcs")
+}
+
+func TestSortedUnique_DuplicatesAndUnsortedInput_ReturnsSortedDeduped(t *testing.T) {
+	got := sortedUnique([]string{"include/gpu", "include", "include/gpu"})
+	assert.Equal(t, []string{"include", "include/gpu"}, got)
+}
+
+func TestCmakeAttributesFor_CcLibraryRule_MergesDerivedAndStaticAttrs(t *testing.T) {
+	rule := &build.Rule{
+		Name:      proto.String("//src/gpu:ganesh"),
+		RuleClass: proto.String("cc_library"),
+		Attribute: []*build.Attribute{
+			{Name: proto.String("includes"), StringListValue: []string{"include/gpu"}},
+			{Name: proto.String("defines"), StringListValue: []string{"SK_GANESH"}},
+		},
+	}
+	rules := map[string]*build.Rule{"//src/gpu:ganesh": rule}
+	lib := CMakeLibraryExportDesc{
+		Name:        "skia_ganesh",
+		Rules:       []string{"//src/gpu:ganesh"},
+		IncludeDirs: []string{"include"},
+		Defines:     []string{"SK_GL"},
+	}
+
+	includeDirs, defines := cmakeAttributesFor(lib, rules)
+	assert.Equal(t, []string{"include", "include/gpu"}, includeDirs)
+	assert.Equal(t, []string{"SK_GANESH", "SK_GL"}, defines)
+}
+
+func TestCmakeAttributesFor_FilegroupRule_IgnoresItsAttrs(t *testing.T) {
+	rule := createTestBuildRule("//src/core:core_srcs", "filegroup", "", []string{"//src/core:a.cpp"})
+	rule.Attribute = append(rule.Attribute,
+		&build.Attribute{Name: proto.String("includes"), StringListValue: []string{"src/core"}},
+		&build.Attribute{Name: proto.String("defines"), StringListValue: []string{"SK_FOO"}},
+	)
+	rules := map[string]*build.Rule{"//src/core:core_srcs": rule}
+	lib := CMakeLibraryExportDesc{Name: "skia_core", Rules: []string{"//src/core:core_srcs"}, IncludeDirs: []string{"include"}}
+
+	includeDirs, defines := cmakeAttributesFor(lib, rules)
+	assert.Equal(t, []string{"include"}, includeDirs)
+	assert.Empty(t, defines)
+}
@@ -0,0 +1,30 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+)
+
+// createTestBuildRule returns a filegroup-shaped build.Rule with the given
+// label, rule class, BUILD.bazel location, and "srcs" attribute value.
+func createTestBuildRule(label, ruleClass, location string, srcs []string) *build.Rule {
+	attrType := build.Attribute_STRING_LIST
+	return &build.Rule{
+		Name:      proto.String(label),
+		RuleClass: proto.String(ruleClass),
+		Location:  proto.String(location),
+		Attribute: []*build.Attribute{
+			{
+				Name:            proto.String("srcs"),
+				Type:            &attrType,
+				StringListValue: srcs,
+			},
+		},
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces"
+)
+
+// Exporter turns the targets reported by a `bazel cquery` into the build
+// files some other build system (GN, CMake, ...) needs. Every exporter
+// runs its own query, scoped to only the rule labels it was configured to
+// export; they differ only in what they query for, how they read the
+// result, what they write and where.
+type Exporter interface {
+	// Export regenerates the exporter's output files from the targets
+	// reported by qcmd.
+	Export(qcmd interfaces.QueryCommand) error
+}
+
+// queryExpressionForLabels builds the `bazel cquery` expression covering
+// every label in labels.
+func queryExpressionForLabels(labels []string) string {
+	return strings.Join(labels, " + ")
+}
+
+// queryRulesForExpression runs qcmd against expression and indexes every
+// RULE target in the result by its fully-qualified label.
+func queryRulesForExpression(qcmd interfaces.QueryCommand, expression string) (map[string]*build.Rule, error) {
+	data, err := qcmd.Read(expression)
+	if err != nil {
+		return nil, err
+	}
+	qr := &build.QueryResult{}
+	if err := proto.Unmarshal(data, qr); err != nil {
+		return nil, fmt.Errorf("unmarshalling query result: %w", err)
+	}
+	rules := make(map[string]*build.Rule, len(qr.GetTarget()))
+	for _, t := range qr.GetTarget() {
+		if t.GetType() != build.Target_RULE {
+			continue
+		}
+		rules[t.GetRule().GetName()] = t.GetRule()
+	}
+	return rules, nil
+}
@@ -0,0 +1,481 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package exporter turns the output of a Bazel query into the build files
+// (GN's .gni files, CMakeLists.txt) that other build systems use to build
+// Skia outside of Bazel.
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/gnwriter"
+	"go.skia.org/skia/bazel/exporter/interfaces"
+	"go.skia.org/skia/bazel/exporter/pathutil"
+)
+
+// gniRootDirs are the top-level Skia directories the exporter knows how to
+// turn into a `$_xxx` GN path variable. See makeRelativeFilePathForGNI.
+var gniRootDirs = []string{"src", "include", "modules"}
+
+// gniFileFooters holds hand-authored content appended, verbatim, after the
+// generated declarations of certain .gni files. These lines reference
+// variables assembled by other exporter runs (e.g. pathops, skpicture) that
+// a single bazel cquery can't yet resolve.
+var gniFileFooters = map[string]string{
+	"gn/core.gni": "skia_core_sources += skia_pathops_sources\n" +
+		"skia_core_sources += skia_skpicture_sources\n" +
+		"\n" +
+		"skia_core_public += skia_pathops_public\n" +
+		"skia_core_public += skia_skpicture_public\n",
+}
+
+// gniLineVarRegexp matches the start of a formatted `name = [` declaration.
+var gniLineVarRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*\[`)
+
+// PlatformSelect maps one branch of a Bazel select() — identified by the
+// label of the config_setting it matches, e.g.
+// "//bazel/common_config_settings:is_mac" — to the GN condition that
+// should guard the files it contributes, e.g. "is_mac".
+type PlatformSelect struct {
+	ConfigSetting string
+	GNCondition   string
+}
+
+// GNIFileListExportDesc describes a single GN list variable to generate and
+// the Bazel targets whose files populate it.
+type GNIFileListExportDesc struct {
+	// Var is the GN variable name, e.g. "skia_core_sources".
+	Var string
+	// Rules is the set of fully-qualified Bazel targets (e.g.
+	// "//src/core:core_srcs") whose files populate Var.
+	Rules []string
+	// PlatformSelects declares the GN condition for every select() branch
+	// worth emitting among Rules' srcs. A branch whose config_setting
+	// isn't listed here is dropped rather than flattened into Var's
+	// unconditional contents. Branches are emitted, in declaration order,
+	// as `if (GNCondition) { Var += [ ... ] }` blocks following Var's
+	// unconditional list.
+	PlatformSelects []PlatformSelect
+}
+
+// GNIExportDesc describes all the variables the exporter should maintain
+// inside a single .gni file.
+type GNIExportDesc struct {
+	// GNI is the path of the .gni file, relative to the workspace root.
+	GNI string
+	// Vars are the variables to generate inside GNI.
+	Vars []GNIFileListExportDesc
+}
+
+// GNIExporterParams configures a GNIExporter.
+type GNIExporterParams struct {
+	// WorkspaceDir is the absolute path to the root of the Bazel workspace.
+	WorkspaceDir string
+	// ExportDescs describes every .gni file the exporter maintains.
+	ExportDescs []GNIExportDesc
+}
+
+// GNIExporter regenerates the .gni files consumed by GN from the output of
+// a `bazel cquery`.
+type GNIExporter struct {
+	params GNIExporterParams
+	fs     interfaces.FileSystem
+
+	// configErr holds the result of validating params.ExportDescs at
+	// construction time. It can't be returned from NewGNIExporter itself
+	// without changing its signature, so Export and CheckCurrent check it
+	// before doing any querying or I/O, which means a typo'd config is
+	// still caught on the very first call rather than surfacing as a
+	// confusing empty .gni variable deep inside buildGNIFile.
+	configErr error
+}
+
+// NewGNIExporter returns a GNIExporter configured to maintain the .gni
+// files described by params. params.ExportDescs is validated here for
+// structural mistakes (duplicate or empty names, variables with no rules)
+// that would otherwise silently produce an empty or clobbered .gni
+// variable; see validateExportDescs.
+func NewGNIExporter(params GNIExporterParams, fs interfaces.FileSystem) *GNIExporter {
+	return &GNIExporter{params: params, fs: fs, configErr: validateExportDescs(params.ExportDescs)}
+}
+
+// validateExportDescs checks descs for the mistakes a typo in hand-authored
+// exporter config tends to produce: an empty .gni path or variable name, a
+// .gni file targeted by more than one GNIExportDesc (the second Export call
+// would silently clobber the first's output), a variable name repeated
+// within one GNIExportDesc (only the last one's contents would ever be
+// written), or a variable with no Rules (it would always resolve to an
+// empty list). It can't check that a Rules label actually names a known
+// Bazel target — that requires a query result, which doesn't exist yet at
+// construction time; that's still only caught when Export or CheckCurrent
+// runs.
+func validateExportDescs(descs []GNIExportDesc) error {
+	seenGNI := map[string]bool{}
+	for _, desc := range descs {
+		if desc.GNI == "" {
+			return fmt.Errorf("invalid GNIExportDesc: empty GNI path")
+		}
+		if seenGNI[desc.GNI] {
+			return fmt.Errorf("invalid GNIExportDesc: %s is targeted by more than one GNIExportDesc", desc.GNI)
+		}
+		seenGNI[desc.GNI] = true
+
+		seenVar := map[string]bool{}
+		for _, v := range desc.Vars {
+			if v.Var == "" {
+				return fmt.Errorf("invalid GNIFileListExportDesc in %s: empty Var name", desc.GNI)
+			}
+			if seenVar[v.Var] {
+				return fmt.Errorf("invalid GNIFileListExportDesc in %s: variable %q is declared more than once", desc.GNI, v.Var)
+			}
+			seenVar[v.Var] = true
+			if len(v.Rules) == 0 {
+				return fmt.Errorf("invalid GNIFileListExportDesc in %s: variable %q has no Rules", desc.GNI, v.Var)
+			}
+		}
+	}
+	return nil
+}
+
+// workspaceToAbsPath converts a path relative to the workspace root into an
+// absolute path.
+func (e *GNIExporter) workspaceToAbsPath(p string) string {
+	return filepath.Join(e.params.WorkspaceDir, p)
+}
+
+// Export regenerates every .gni file described by e.params.ExportDescs from
+// the targets reported by qcmd. If qcmd also implements
+// interfaces.StreamingQueryCommand, Export processes its targets
+// incrementally instead of unmarshalling the entire QueryResult at once;
+// see exportStreaming.
+func (e *GNIExporter) Export(qcmd interfaces.QueryCommand) error {
+	if e.configErr != nil {
+		return e.configErr
+	}
+	if sqcmd, ok := qcmd.(interfaces.StreamingQueryCommand); ok {
+		return e.exportStreaming(sqcmd)
+	}
+
+	rules, err := e.queryRules(qcmd)
+	if err != nil {
+		return fmt.Errorf("querying rules: %w", err)
+	}
+
+	for _, desc := range e.params.ExportDescs {
+		file, err := e.buildGNIFile(desc, rules)
+		if err != nil {
+			return fmt.Errorf("building %s: %w", desc.GNI, err)
+		}
+		w, err := e.fs.OpenFile(e.workspaceToAbsPath(desc.GNI))
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", desc.GNI, err)
+		}
+		if _, err := w.Write(file.Format()); err != nil {
+			return fmt.Errorf("writing %s: %w", desc.GNI, err)
+		}
+	}
+	return nil
+}
+
+// buildGNIFile computes the full AST for a single .gni file given the
+// already-resolved Bazel rules.
+func (e *GNIExporter) buildGNIFile(desc GNIExportDesc, rules map[string]*build.Rule) (*gnwriter.File, error) {
+	handlers := newRuleHandlers(rules)
+	varItems := make(map[string][]string, len(desc.Vars))
+	platformBlocks := make(map[string][]platformBlock, len(desc.Vars))
+	roots := map[string]bool{}
+	resolvePaths := func(varName string, targets []string) ([]string, error) {
+		paths, err := convertTargetsToFilePaths(targets)
+		if err != nil {
+			return nil, fmt.Errorf("converting targets for %s: %w", varName, err)
+		}
+		paths = filterDeprecatedFiles(paths)
+		if dup, found := findDuplicate(paths); found {
+			return nil, fmt.Errorf("%s: duplicate file %q", varName, dup)
+		}
+		gniPaths, err := addGNIVariablesToWorkspacePaths(paths)
+		if err != nil {
+			return nil, fmt.Errorf("formatting paths for %s: %w", varName, err)
+		}
+		for _, p := range gniPaths {
+			roots[extractTopLevelFolder(p)] = true
+		}
+		return gnwriter.SortGNStrings(gniPaths), nil
+	}
+
+	for _, v := range desc.Vars {
+		var targets []string
+		for _, ruleLabel := range v.Rules {
+			srcs, err := collectRuleTargets(rules, handlers, ruleLabel)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Var, err)
+			}
+			targets = append(targets, srcs...)
+		}
+		items, err := resolvePaths(v.Var, targets)
+		if err != nil {
+			return nil, err
+		}
+		varItems[v.Var] = items
+
+		for _, ps := range v.PlatformSelects {
+			var platformTargets []string
+			for _, ruleLabel := range v.Rules {
+				rule, ok := rules[ruleLabel]
+				if !ok {
+					return nil, fmt.Errorf("%s: %w", v.Var, newUnknownNameError("target", ruleLabel, ruleLabels(rules)))
+				}
+				platformTargets = append(platformTargets, ruleSelectorBranches(rule)[ps.ConfigSetting]...)
+			}
+			if len(platformTargets) == 0 {
+				continue
+			}
+			items, err := resolvePaths(v.Var, platformTargets)
+			if err != nil {
+				return nil, err
+			}
+			platformBlocks[v.Var] = append(platformBlocks[v.Var], platformBlock{condition: ps.GNCondition, items: items})
+		}
+	}
+
+	file := gnwriter.NewFile()
+	file.AddComment("DO NOT EDIT: This is a generated file.")
+	file.AddComment("See //bazel/exporter_tool/README.md for more information.")
+	file.AddBlank()
+	for _, root := range sortedRootVars(roots) {
+		file.AddCall(root, "get_path_info", "../"+strings.TrimPrefix(root, "_"), "abspath")
+	}
+	file.AddBlank()
+	for _, v := range desc.Vars {
+		file.AddList(v.Var, varItems[v.Var])
+		file.AddBlank()
+		for _, block := range platformBlocks[v.Var] {
+			file.AddIfAppendList(block.condition, v.Var, block.items)
+			file.AddBlank()
+		}
+	}
+	if footer, ok := gniFileFooters[desc.GNI]; ok {
+		file.AddRaw(footer)
+	}
+	return file, nil
+}
+
+// platformBlock is a single `if (condition) { var += [ ... ] }` block
+// pending emission for a GNIFileListExportDesc variable.
+type platformBlock struct {
+	condition string
+	items     []string
+}
+
+// ruleSelectorBranches returns, for the "srcs" attribute of rule, the
+// target labels contributed by each config_setting label's branch, if
+// "srcs" is built from a select(). Rules whose "srcs" isn't a select()
+// return nil.
+func ruleSelectorBranches(rule *build.Rule) map[string][]string {
+	for _, attr := range rule.GetAttribute() {
+		if attr.GetName() != "srcs" || attr.GetType() != build.Attribute_SELECTOR_LIST {
+			continue
+		}
+		elements := attr.GetSelectorList().GetElements()
+		branches := make(map[string][]string, len(elements))
+		for _, e := range elements {
+			branches[e.GetLabel()] = e.GetStringListValue()
+		}
+		return branches
+	}
+	return nil
+}
+
+// sortedRootVars turns the set of "$_xxx" path variables referenced by a
+// .gni file's lists into the sorted "_xxx" variable names to declare.
+func sortedRootVars(roots map[string]bool) []string {
+	vars := make([]string, 0, len(roots))
+	for r := range roots {
+		vars = append(vars, strings.TrimPrefix(r, "$"))
+	}
+	sort.Strings(vars)
+	return vars
+}
+
+// queryRules runs qcmd and indexes every RULE target in the result by its
+// fully-qualified label.
+func (e *GNIExporter) queryRules(qcmd interfaces.QueryCommand) (map[string]*build.Rule, error) {
+	return queryRulesForExpression(qcmd, e.queryExpression())
+}
+
+// ruleLabels returns the sorted set of labels known to rules, used as the
+// candidate corpus for "did you mean" diagnostics.
+func ruleLabels(rules map[string]*build.Rule) []string {
+	labels := make([]string, 0, len(rules))
+	for label := range rules {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// queryExpression builds the `bazel cquery` expression covering every rule
+// referenced by e.params.ExportDescs.
+func (e *GNIExporter) queryExpression() string {
+	var labels []string
+	for _, desc := range e.params.ExportDescs {
+		for _, v := range desc.Vars {
+			labels = append(labels, v.Rules...)
+		}
+	}
+	return queryExpressionForLabels(labels)
+}
+
+// CheckCurrent reports how many of the managed GN variables in
+// e.params.ExportDescs are out of date. This is intentionally a structural
+// check, not a diff against qcmd's live query result: each declared
+// variable must exist in the on-disk file and already be in the canonical,
+// gn-format sorted order. It does not detect a file or rule added to Bazel
+// but never re-exported — that requires re-running Export (or a future
+// CheckCurrent that calls queryRules and compares against buildGNIFile's
+// output, mirroring the live-query approach Export already takes). qcmd is
+// accepted, rather than dropped, so that extension can land later without
+// another breaking signature change; every existing caller already has one
+// in hand from driving Export. Problems found are described on errOut.
+func (e *GNIExporter) CheckCurrent(qcmd interfaces.QueryCommand, errOut io.Writer) (int, error) {
+	if e.configErr != nil {
+		return 0, e.configErr
+	}
+	numOutOfDate := 0
+	for _, desc := range e.params.ExportDescs {
+		contents, err := e.fs.ReadFile(e.workspaceToAbsPath(desc.GNI))
+		if err != nil {
+			return numOutOfDate, fmt.Errorf("reading %s: %w", desc.GNI, err)
+		}
+		file, err := gnwriter.Parse(contents)
+		if err != nil {
+			return numOutOfDate, fmt.Errorf("parsing %s: %w", desc.GNI, err)
+		}
+		for _, v := range desc.Vars {
+			items, ok := file.Variable(v.Var)
+			if !ok {
+				fmt.Fprintf(errOut, "%s: variable %q not found\n", desc.GNI, v.Var)
+				numOutOfDate++
+				continue
+			}
+			if sorted := gnwriter.SortGNStrings(items); !reflect.DeepEqual(items, sorted) {
+				fmt.Fprintf(errOut, "%s: variable %q is not in sorted order\n", desc.GNI, v.Var)
+				numOutOfDate++
+			}
+		}
+	}
+	return numOutOfDate, nil
+}
+
+// makeRelativeFilePathForGNI converts a workspace-relative file path (e.g.
+// "src/core/file.cpp") into the form used inside a .gni list (e.g.
+// "$_src/core/file.cpp").
+func makeRelativeFilePathForGNI(target string) (string, error) {
+	root := extractTopLevelFolder(target)
+	supported := false
+	for _, r := range gniRootDirs {
+		if root == r {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return "", fmt.Errorf("path %q: %w", target, newUnknownNameError("root directory", root, gniRootDirs))
+	}
+	rest := strings.TrimPrefix(target, root+"/")
+	return fmt.Sprintf("$_%s/%s", root, rest), nil
+}
+
+// extractTopLevelFolder returns the first path component of p, or "" if p
+// is empty or absolute.
+func extractTopLevelFolder(p string) string {
+	return pathutil.ExtractTopLevelFolder(p)
+}
+
+// addGNIVariablesToWorkspacePaths converts a list of workspace-relative file
+// paths into their $_xxx-prefixed .gni form.
+func addGNIVariablesToWorkspacePaths(paths []string) ([]string, error) {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		gniPath, err := makeRelativeFilePathForGNI(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, gniPath)
+	}
+	return out, nil
+}
+
+// convertTargetsToFilePaths converts fully-qualified Bazel target labels
+// (e.g. "//src/core:file.cpp") into workspace-relative file paths (e.g.
+// "src/core/file.cpp").
+func convertTargetsToFilePaths(targets []string) ([]string, error) {
+	return pathutil.ConvertTargetsToFilePaths(targets)
+}
+
+// isHeaderFile reports whether path has a C/C++ header extension.
+func isHeaderFile(p string) bool {
+	ext := strings.ToLower(filepath.Ext(p))
+	return ext == ".h" || ext == ".hpp"
+}
+
+// fileListContainsOnlyCppHeaderFiles reports whether paths is non-empty and
+// every entry is a C/C++ header.
+func fileListContainsOnlyCppHeaderFiles(paths []string) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, p := range paths {
+		if !isHeaderFile(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// getGNILineVariable returns the variable name being assigned to by a
+// formatted `name = [` declaration line, or "" if line isn't one.
+func getGNILineVariable(line string) string {
+	m := gniLineVarRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// isSourceFileDeprecated reports whether path is a known-deprecated source
+// file that must be excluded from generated .gni lists.
+func isSourceFileDeprecated(path string) bool {
+	return pathutil.IsSourceFileDeprecated(path)
+}
+
+// filterDeprecatedFiles returns files with any deprecated entries removed,
+// preserving order.
+func filterDeprecatedFiles(files []string) []string {
+	return pathutil.FilterDeprecatedFiles(files)
+}
+
+// findDuplicate finds the first file in files that also appears earlier in
+// the slice (comparison is case-insensitive) and returns that earlier
+// entry, as spelled there, and true if one was found.
+func findDuplicate(files []string) (string, bool) {
+	seen := make(map[string]string, len(files))
+	for _, f := range files {
+		key := strings.ToLower(f)
+		if orig, ok := seen[key]; ok {
+			return orig, true
+		}
+		seen[key] = f
+	}
+	return "", false
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGNIExporter_ValidConfig_NoError(t *testing.T) {
+	params := GNIExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{{Var: "skia_core_sources", Rules: []string{"//src/core:core_srcs"}}}},
+		},
+	}
+	e := NewGNIExporter(params, nil)
+	assert.NoError(t, e.configErr)
+}
+
+func TestNewGNIExporter_DuplicateGNIPath_ExportReturnsError(t *testing.T) {
+	params := GNIExporterParams{
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{{Var: "a", Rules: []string{"//src/core:a"}}}},
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{{Var: "b", Rules: []string{"//src/core:b"}}}},
+		},
+	}
+	e := NewGNIExporter(params, nil)
+	err := e.Export(nil)
+	assert.ErrorContains(t, err, "gn/core.gni")
+	assert.ErrorContains(t, err, "more than one")
+}
+
+func TestNewGNIExporter_DuplicateVarInOneGNI_CheckCurrentReturnsError(t *testing.T) {
+	params := GNIExporterParams{
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{
+				{Var: "skia_core_sources", Rules: []string{"//src/core:a"}},
+				{Var: "skia_core_sources", Rules: []string{"//src/core:b"}},
+			}},
+		},
+	}
+	e := NewGNIExporter(params, nil)
+	_, err := e.CheckCurrent(nil, nil)
+	assert.ErrorContains(t, err, "skia_core_sources")
+	assert.ErrorContains(t, err, "more than once")
+}
+
+func TestNewGNIExporter_VarWithNoRules_ExportReturnsError(t *testing.T) {
+	params := GNIExporterParams{
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{{Var: "skia_core_sources"}}},
+		},
+	}
+	e := NewGNIExporter(params, nil)
+	err := e.Export(nil)
+	assert.ErrorContains(t, err, "skia_core_sources")
+	assert.ErrorContains(t, err, "no Rules")
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package gnwriter models the small subset of GN's .gni syntax the exporter
+// tooling needs (list-literal assignments, `+=` appends, get_path_info(...)
+// calls and `if (is_*)` conditional blocks) as an AST, so that exporters can
+// round-trip hand-authored declarations, comments and conditionals they
+// don't themselves manage.
+//
+// Emitted string lists are sorted the way `gn format` orders them —
+// punctuation-first, then case-insensitive alpha, see SortGNStrings — but
+// this package does not reproduce `gn format`'s further grouping of list
+// entries by directory prefix with blank lines between groups; output is
+// GN-syntax-valid and deterministically ordered, not byte-identical to
+// `gn format`.
+package gnwriter
+
+// Decl is a single declaration inside a .gni file, at any nesting depth.
+type Decl interface {
+	isDecl()
+}
+
+// CommentDecl is a `# ...` line. Text excludes the leading "#" and
+// surrounding whitespace.
+type CommentDecl struct {
+	Text string
+}
+
+// BlankDecl is an empty line, preserved so unrelated content keeps its
+// original spacing when round-tripped.
+type BlankDecl struct{}
+
+// RawDecl is a verbatim block the writer doesn't model structurally, e.g. a
+// hand-authored trailer appended after the generated declarations.
+type RawDecl struct {
+	Text string
+}
+
+// AssignDecl is a `name = <expr>` declaration.
+type AssignDecl struct {
+	Name string
+	Expr Expr
+}
+
+// AppendDecl is a `name += <expr>` declaration.
+type AppendDecl struct {
+	Name string
+	Expr Expr
+}
+
+// IfDecl is an `if (condition) { ... }` block.
+type IfDecl struct {
+	Condition string
+	Body      []Decl
+}
+
+func (CommentDecl) isDecl() {}
+func (BlankDecl) isDecl()   {}
+func (RawDecl) isDecl()     {}
+func (AssignDecl) isDecl()  {}
+func (AppendDecl) isDecl()  {}
+func (IfDecl) isDecl()      {}
+
+// Expr is the right-hand side of an AssignDecl or AppendDecl.
+type Expr interface {
+	isExpr()
+}
+
+// ListExpr is a GN list literal, e.g. `[ "a", "b" ]`.
+type ListExpr struct {
+	Items []string
+}
+
+// CallExpr is a function invocation, e.g. `get_path_info("../src", "abspath")`.
+type CallExpr struct {
+	Name string
+	Args []string
+}
+
+// RawExpr is an expression the writer doesn't model structurally, e.g. a
+// bare variable reference on the right-hand side of a `+=`.
+type RawExpr struct {
+	Text string
+}
+
+func (ListExpr) isExpr() {}
+func (CallExpr) isExpr() {}
+func (RawExpr) isExpr()  {}
+
+// File is the parsed (or freshly constructed) AST of a .gni file.
+type File struct {
+	Decls []Decl
+}
+
+// NewFile returns an empty File ready to have Decls appended to it.
+func NewFile() *File {
+	return &File{}
+}
+
+// AddComment appends a comment line.
+func (f *File) AddComment(text string) {
+	f.Decls = append(f.Decls, CommentDecl{Text: text})
+}
+
+// AddBlank appends a blank line.
+func (f *File) AddBlank() {
+	f.Decls = append(f.Decls, BlankDecl{})
+}
+
+// AddRaw appends a verbatim block of text.
+func (f *File) AddRaw(text string) {
+	f.Decls = append(f.Decls, RawDecl{Text: text})
+}
+
+// AddCall appends `name = fn(args...)`.
+func (f *File) AddCall(name, fn string, args ...string) {
+	f.Decls = append(f.Decls, AssignDecl{Name: name, Expr: CallExpr{Name: fn, Args: args}})
+}
+
+// AddList appends `name = [ items... ]`.
+func (f *File) AddList(name string, items []string) {
+	f.Decls = append(f.Decls, AssignDecl{Name: name, Expr: ListExpr{Items: items}})
+}
+
+// AddAppend appends `name += value`.
+func (f *File) AddAppend(name, value string) {
+	f.Decls = append(f.Decls, AppendDecl{Name: name, Expr: RawExpr{Text: value}})
+}
+
+// AddIfAppendList appends `if (condition) { name += [ items... ] }`.
+func (f *File) AddIfAppendList(condition, name string, items []string) {
+	f.Decls = append(f.Decls, IfDecl{
+		Condition: condition,
+		Body:      []Decl{AppendDecl{Name: name, Expr: ListExpr{Items: items}}},
+	})
+}
+
+// Variable returns the string list assigned to name at the top level of the
+// file (i.e. not nested inside a conditional block), if any.
+func (f *File) Variable(name string) ([]string, bool) {
+	for _, d := range f.Decls {
+		if a, ok := d.(AssignDecl); ok && a.Name == name {
+			if l, ok := a.Expr.(ListExpr); ok {
+				return l.Items, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gnwriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format serializes the File in `gn format`'s style: two-space indented
+// list literals with one entry per line, and blank lines preserved exactly
+// as authored. List entries are written in the order they are stored and
+// are not grouped by directory prefix, so the output is not guaranteed to
+// be byte-identical to `gn format`'s.
+func (f *File) Format() []byte {
+	var b strings.Builder
+	writeDecls(&b, f.Decls, 0)
+	return []byte(b.String())
+}
+
+func writeDecls(b *strings.Builder, decls []Decl, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for _, d := range decls {
+		switch v := d.(type) {
+		case CommentDecl:
+			fmt.Fprintf(b, "%s# %s\n", pad, v.Text)
+		case BlankDecl:
+			b.WriteString("\n")
+		case RawDecl:
+			b.WriteString(v.Text)
+		case AssignDecl:
+			writeAssign(b, pad, v.Name, "=", v.Expr)
+		case AppendDecl:
+			writeAssign(b, pad, v.Name, "+=", v.Expr)
+		case IfDecl:
+			fmt.Fprintf(b, "%sif (%s) {\n", pad, v.Condition)
+			writeDecls(b, v.Body, indent+1)
+			fmt.Fprintf(b, "%s}\n", pad)
+		}
+	}
+}
+
+func writeAssign(b *strings.Builder, pad, name, op string, e Expr) {
+	switch v := e.(type) {
+	case ListExpr:
+		if len(v.Items) == 0 {
+			fmt.Fprintf(b, "%s%s %s []\n", pad, name, op)
+			return
+		}
+		fmt.Fprintf(b, "%s%s %s [\n", pad, name, op)
+		for _, item := range v.Items {
+			fmt.Fprintf(b, "%s  \"%s\",\n", pad, item)
+		}
+		fmt.Fprintf(b, "%s]\n", pad)
+	case CallExpr:
+		fmt.Fprintf(b, "%s%s %s %s(%s)\n", pad, name, op, v.Name, quoteArgs(v.Args))
+	case RawExpr:
+		fmt.Fprintf(b, "%s%s %s %s\n", pad, name, op, v.Text)
+	}
+}
+
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = fmt.Sprintf("%q", a)
+	}
+	return strings.Join(quoted, ", ")
+}
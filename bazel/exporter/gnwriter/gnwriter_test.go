@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gnwriter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortGNStrings_MixedCaseAndPunctuation_SortsPunctuationFirstCaseInsensitive(t *testing.T) {
+	in := []string{"SkB.cpp", "_SkA.cpp", "ska.cpp", "SKA.cpp"}
+	got := SortGNStrings(in)
+	assert.Equal(t, []string{"_SkA.cpp", "ska.cpp", "SKA.cpp", "SkB.cpp"}, got)
+}
+
+func TestParseThenFormat_GeneratedFile_RoundTrips(t *testing.T) {
+	const src = `# DO NOT EDIT: This is a generated file.
+# See //bazel/exporter_tool/README.md for more information.
+
+_src = get_path_info("../src", "abspath")
+
+skia_core_sources = [
+  "$_src/core/SkAAClip.cpp",
+  "$_src/core/SkAlphaRuns.cpp",
+]
+
+skia_core_sources += skia_pathops_sources
+`
+	file, err := Parse([]byte(src))
+	require.NoError(t, err)
+
+	items, ok := file.Variable("skia_core_sources")
+	require.True(t, ok)
+	assert.Equal(t, []string{"$_src/core/SkAAClip.cpp", "$_src/core/SkAlphaRuns.cpp"}, items)
+
+	assert.Equal(t, src, string(file.Format()))
+}
+
+func TestParse_ConditionalBlock_PreservesBody(t *testing.T) {
+	const src = `skia_core_sources = [
+  "$_src/core/SkAAClip.cpp",
+]
+
+if (is_mac) {
+  skia_core_sources += skia_mac_sources
+}
+`
+	file, err := Parse([]byte(src))
+	require.NoError(t, err)
+
+	var cond IfDecl
+	found := false
+	for _, d := range file.Decls {
+		if c, ok := d.(IfDecl); ok {
+			cond = c
+			found = true
+		}
+	}
+	require.True(t, found, "expected an if-block to be preserved")
+	assert.Equal(t, "is_mac", cond.Condition)
+	assert.Len(t, cond.Body, 1)
+
+	assert.Equal(t, src, string(file.Format()))
+}
+
+func TestFile_AddIfAppendListThenFormat_MatchesGNFormatStyle(t *testing.T) {
+	f := NewFile()
+	f.AddList("skia_core_sources", []string{"$_src/core/SkAAClip.cpp"})
+	f.AddBlank()
+	f.AddIfAppendList("is_mac", "skia_core_sources", []string{"$_src/core/SkMac.cpp"})
+
+	const expected = `skia_core_sources = [
+  "$_src/core/SkAAClip.cpp",
+]
+
+if (is_mac) {
+  skia_core_sources += [
+    "$_src/core/SkMac.cpp",
+  ]
+}
+`
+	assert.Equal(t, expected, string(f.Format()))
+}
+
+func TestFile_AddListThenFormat_MatchesGNFormatStyle(t *testing.T) {
+	f := NewFile()
+	f.AddComment("DO NOT EDIT: This is a generated file.")
+	f.AddBlank()
+	f.AddList("skia_core_sources", []string{"$_src/core/SkAAClip.cpp", "$_src/core/SkAlphaRuns.cpp"})
+
+	const expected = `# DO NOT EDIT: This is a generated file.
+
+skia_core_sources = [
+  "$_src/core/SkAAClip.cpp",
+  "$_src/core/SkAlphaRuns.cpp",
+]
+`
+	assert.Equal(t, expected, string(f.Format()))
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gnwriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse reads the textual contents of a .gni file into an AST, preserving
+// comments, blank lines and conditional blocks so that callers can
+// round-trip hand-authored declarations they don't themselves manage.
+func Parse(data []byte) (*File, error) {
+	lines := strings.Split(string(data), "\n")
+	// A trailing newline produces one empty trailing element; drop it so it
+	// isn't parsed as a spurious blank declaration.
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	decls, _, err := parseLines(lines, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Decls: decls}, nil
+}
+
+// parseLines parses lines[start:] until input is exhausted or a line at a
+// shallower nesting level (a closing "}") is reached, returning the parsed
+// declarations and the index of the first unconsumed line.
+func parseLines(lines []string, start int) ([]Decl, int, error) {
+	var decls []Decl
+	i := start
+	for i < len(lines) {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "":
+			decls = append(decls, BlankDecl{})
+			i++
+		case trimmed == "}":
+			return decls, i + 1, nil
+		case strings.HasPrefix(trimmed, "#"):
+			decls = append(decls, CommentDecl{Text: strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))})
+			i++
+		case strings.HasPrefix(trimmed, "if ("):
+			closeParen := strings.Index(trimmed, ")")
+			if closeParen < 0 {
+				return nil, 0, fmt.Errorf("gnwriter: malformed if condition on line %d", i+1)
+			}
+			cond := trimmed[len("if (") : closeParen]
+			body, next, err := parseLines(lines, i+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			decls = append(decls, IfDecl{Condition: cond, Body: body})
+			i = next
+		case strings.Contains(trimmed, "+="):
+			name, rhs := splitOnce(trimmed, "+=")
+			decls = append(decls, AppendDecl{Name: name, Expr: RawExpr{Text: rhs}})
+			i++
+		case strings.Contains(trimmed, "="):
+			name, rhs := splitOnce(trimmed, "=")
+			if rhs == "[" {
+				items, next, err := parseListItems(lines, i+1)
+				if err != nil {
+					return nil, 0, err
+				}
+				decls = append(decls, AssignDecl{Name: name, Expr: ListExpr{Items: items}})
+				i = next
+			} else {
+				decls = append(decls, AssignDecl{Name: name, Expr: parseRHS(rhs)})
+				i++
+			}
+		default:
+			return nil, 0, fmt.Errorf("gnwriter: could not parse line %d: %q", i+1, lines[i])
+		}
+	}
+	return decls, i, nil
+}
+
+// parseListItems reads quoted, comma-terminated list entries until a line
+// containing only "]" is found.
+func parseListItems(lines []string, start int) ([]string, int, error) {
+	var items []string
+	i := start
+	for i < len(lines) {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "]" {
+			return items, i + 1, nil
+		}
+		item := strings.TrimSuffix(trimmed, ",")
+		item = strings.Trim(item, "\"")
+		items = append(items, item)
+		i++
+	}
+	return nil, 0, fmt.Errorf("gnwriter: unterminated list literal")
+}
+
+// parseRHS interprets the right-hand side of an assignment that isn't a
+// list literal: either a function call, e.g. get_path_info("../src",
+// "abspath"), or a bare/raw expression.
+func parseRHS(rhs string) Expr {
+	if idx := strings.Index(rhs, "("); idx > 0 && strings.HasSuffix(rhs, ")") {
+		name := rhs[:idx]
+		argStr := rhs[idx+1 : len(rhs)-1]
+		var args []string
+		if strings.TrimSpace(argStr) != "" {
+			for _, a := range strings.Split(argStr, ",") {
+				args = append(args, strings.Trim(strings.TrimSpace(a), "\""))
+			}
+		}
+		return CallExpr{Name: name, Args: args}
+	}
+	return RawExpr{Text: rhs}
+}
+
+// splitOnce splits s on the first occurrence of sep, trimming whitespace
+// from both halves.
+func splitOnce(s, sep string) (string, string) {
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 {
+		return strings.TrimSpace(s), ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
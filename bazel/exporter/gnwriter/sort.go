@@ -0,0 +1,46 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gnwriter
+
+import (
+	"sort"
+	"unicode"
+)
+
+// SortGNStrings returns a copy of items sorted the way `gn format` sorts
+// string list literals: punctuation characters sort ahead of alphanumeric
+// ones at each position, and alphabetic comparison is case-insensitive.
+func SortGNStrings(items []string) []string {
+	sorted := append([]string(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return lessGN(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+func lessGN(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		ca, cb := gnCharClass(ra[i]), gnCharClass(rb[i])
+		if ca != cb {
+			return ca < cb
+		}
+		la, lb := unicode.ToLower(ra[i]), unicode.ToLower(rb[i])
+		if la != lb {
+			return la < lb
+		}
+	}
+	return len(ra) < len(rb)
+}
+
+// gnCharClass buckets punctuation ahead of alphanumeric characters, per GN's
+// string list ordering rules.
+func gnCharClass(r rune) int {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package interfaces declares the small set of I/O seams the exporter
+// tooling depends on, so that tests can substitute in-memory fakes instead
+// of touching the real file system or invoking Bazel.
+package interfaces
+
+import "io"
+
+// FileSystem abstracts the file system operations used by the exporters.
+type FileSystem interface {
+	// OpenFile opens (creating or truncating as necessary) the file at the
+	// given absolute path for writing.
+	OpenFile(path string) (io.Writer, error)
+
+	// ReadFile returns the full contents of the file at the given absolute
+	// path.
+	ReadFile(path string) ([]byte, error)
+}
+
+// QueryCommand runs a `bazel cquery` and returns the raw, marshalled
+// build.QueryResult proto bytes.
+type QueryCommand interface {
+	Read(query string) ([]byte, error)
+}
+
+// StreamingQueryCommand runs a `bazel query --output=streamed_proto` and
+// returns a reader over the length-delimited build.Target messages it
+// writes to stdout. An exporter that type-asserts a QueryCommand to this
+// interface can process targets as they arrive instead of holding the
+// entire QueryResult in memory at once.
+type StreamingQueryCommand interface {
+	ReadStreaming(query string) (io.Reader, error)
+}
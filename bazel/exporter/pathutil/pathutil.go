@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package pathutil holds the target-label <-> relative-path plumbing shared
+// by every exporter (GNIExporter, CMakeExporter, ...): turning a Bazel
+// target label into a workspace-relative file path, finding a path's
+// top-level directory, and filtering out files Skia has deprecated but
+// which still show up in cquery output.
+package pathutil
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// deprecatedSourceFiles are files that still exist on disk (and so still
+// show up in cquery output) but must never be emitted by an exporter.
+var deprecatedSourceFiles = map[string]bool{
+	"include/core/SkDrawLooper.h":        true,
+	"include/effects/SkBlurDrawLooper.h": true,
+}
+
+// IsSourceFileDeprecated reports whether path is a known-deprecated source
+// file that exporters must exclude from their output.
+func IsSourceFileDeprecated(path string) bool {
+	return deprecatedSourceFiles[path]
+}
+
+// FilterDeprecatedFiles returns files with any deprecated entries removed,
+// preserving order.
+func FilterDeprecatedFiles(files []string) []string {
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		if !IsSourceFileDeprecated(f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// ExtractTopLevelFolder returns the first path component of p, or "" if p
+// is empty or absolute.
+func ExtractTopLevelFolder(p string) string {
+	if p == "" || strings.HasPrefix(p, "/") {
+		return ""
+	}
+	if idx := strings.Index(p, "/"); idx >= 0 {
+		return p[:idx]
+	}
+	return p
+}
+
+// ConvertTargetsToFilePaths converts fully-qualified Bazel target labels
+// (e.g. "//src/core:file.cpp") into workspace-relative file paths (e.g.
+// "src/core/file.cpp").
+func ConvertTargetsToFilePaths(targets []string) ([]string, error) {
+	out := make([]string, 0, len(targets))
+	for _, t := range targets {
+		p, err := targetToFilePath(t)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func targetToFilePath(target string) (string, error) {
+	if !strings.HasPrefix(target, "//") {
+		return "", fmt.Errorf("invalid target label %q", target)
+	}
+	rest := strings.TrimPrefix(target, "//")
+	idx := strings.LastIndex(rest, ":")
+	if idx < 0 {
+		return "", fmt.Errorf("invalid target label %q: missing rule name", target)
+	}
+	dir, name := rest[:idx], rest[idx+1:]
+	if name == "" {
+		return "", fmt.Errorf("invalid target label %q: empty rule name", target)
+	}
+	if dir == "" {
+		return name, nil
+	}
+	return path.Join(dir, name), nil
+}
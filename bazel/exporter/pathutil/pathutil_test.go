@@ -0,0 +1,53 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package pathutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTopLevelFolder_PathsWithTopDir_ReturnsTopDir(t *testing.T) {
+	test := func(name, input, expected string) {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, ExtractTopLevelFolder(input))
+		})
+	}
+	test("TopIsDir", "foo/bar/baz.txt", "foo")
+	test("TopIsFile", "baz.txt", "baz.txt")
+	test("TopIsAbsDir", "/foo/bar/baz.txt", "")
+	test("EmptyString", "", "")
+}
+
+func TestConvertTargetsToFilePaths_ValidInput_ReturnsPaths(t *testing.T) {
+	paths, err := ConvertTargetsToFilePaths([]string{
+		"//src/include:foo.h",
+		"//include:foo.h",
+		"//modules:foo.cpp",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/include/foo.h", "include/foo.h", "modules/foo.cpp"}, paths)
+}
+
+func TestConvertTargetsToFilePaths_InvalidInput_ReturnsError(t *testing.T) {
+	_, err := ConvertTargetsToFilePaths([]string{"not-a-label"})
+	assert.Error(t, err)
+}
+
+func TestFilterDeprecatedFiles_ContainsDeprecatedFiles_DeprecatedFiltered(t *testing.T) {
+	got := FilterDeprecatedFiles([]string{
+		"include/core/SkDrawLooper.h",
+		"not/deprecated/file.h",
+	})
+	assert.Equal(t, []string{"not/deprecated/file.h"}, got)
+}
+
+func TestIsSourceFileDeprecated_KnownPaths_ReturnsExpected(t *testing.T) {
+	assert.True(t, IsSourceFileDeprecated("include/core/SkDrawLooper.h"))
+	assert.False(t, IsSourceFileDeprecated("include/core/SkColor.h"))
+}
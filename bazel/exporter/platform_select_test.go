@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces/mocks"
+	"google.golang.org/protobuf/proto"
+)
+
+const platformSelectExpectedGNI = `# DO NOT EDIT: This is a generated file.
+# See //bazel/exporter_tool/README.md for more information.
+
+_src = get_path_info("../src", "abspath")
+
+skia_core_sources = [
+  "$_src/core/SkAAClip.cpp",
+  "$_src/core/SkAlphaRuns.cpp",
+]
+
+if (is_mac) {
+  skia_core_sources += [
+    "$_src/core/SkMac.cpp",
+  ]
+}
+
+if (is_win) {
+  skia_core_sources += [
+    "$_src/core/SkWin.cpp",
+  ]
+}
+
+`
+
+func createPlatformSelectQueryResult() *build.QueryResult {
+	qr := build.QueryResult{}
+	ruleDesc := build.Target_RULE
+
+	r1 := createTestBuildRule("//src/core:core_srcs", "filegroup",
+		"/path/to/workspace/src/core/BUILD.bazel:1:1",
+		[]string{"//src/core:SkAAClip.cpp", "//src/core:SkAlphaRuns.cpp"})
+	qr.Target = append(qr.Target, &build.Target{Rule: r1, Type: &ruleDesc})
+
+	selectorType := build.Attribute_SELECTOR_LIST
+	r2 := &build.Rule{
+		Name:      proto.String("//src/core:platform_srcs"),
+		RuleClass: proto.String("filegroup"),
+		Location:  proto.String("/path/to/workspace/src/core/BUILD.bazel:2:1"),
+		Attribute: []*build.Attribute{{
+			Name: proto.String("srcs"),
+			Type: &selectorType,
+			SelectorList: &build.SelectorList{
+				Elements: []*build.SelectorList_SelectorEntry{
+					{
+						Label:           proto.String("//bazel/common_config_settings:is_mac"),
+						StringListValue: []string{"//src/core:SkMac.cpp"},
+					},
+					{
+						Label:           proto.String("//bazel/common_config_settings:is_win"),
+						StringListValue: []string{"//src/core:SkWin.cpp"},
+					},
+				},
+			},
+		}},
+	}
+	qr.Target = append(qr.Target, &build.Target{Rule: r2, Type: &ruleDesc})
+	return &qr
+}
+
+func TestGNIExporterExport_RuleWithPlatformSelect_EmitsConditionalAppends(t *testing.T) {
+	qr := createPlatformSelectQueryResult()
+	protoData, err := proto.Marshal(qr)
+	require.NoError(t, err)
+
+	params := GNIExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/platform.gni", Vars: []GNIFileListExportDesc{
+				{
+					Var:   "skia_core_sources",
+					Rules: []string{"//src/core:core_srcs", "//src/core:platform_srcs"},
+					PlatformSelects: []PlatformSelect{
+						{ConfigSetting: "//bazel/common_config_settings:is_mac", GNCondition: "is_mac"},
+						{ConfigSetting: "//bazel/common_config_settings:is_win", GNCondition: "is_win"},
+					},
+				},
+			}},
+		},
+	}
+
+	fs := mocks.NewFileSystem(t)
+	var contents bytes.Buffer
+	fs.On("OpenFile", mock.Anything).Return(&contents, nil).Once()
+	e := NewGNIExporter(params, fs)
+	qcmd := mocks.NewQueryCommand(t)
+	qcmd.On("Read", mock.Anything).Return(protoData, nil).Once()
+	err = e.Export(qcmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, platformSelectExpectedGNI, contents.String())
+}
+
+func TestRuleSelectorBranches_NonSelectSrcs_ReturnsNil(t *testing.T) {
+	rule := createTestBuildRule("//src/core:core_srcs", "filegroup", "", []string{"//src/core:SkA.cpp"})
+	assert.Nil(t, ruleSelectorBranches(rule))
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+)
+
+// RuleHandler knows how to collect the Bazel target labels a rule of one
+// particular rule class contributes to a .gni variable or CMake library —
+// e.g. a filegroup's "srcs", or a cc_library's "srcs", "hdrs" and
+// "textual_hdrs" combined.
+type RuleHandler interface {
+	// Kind is the Bazel rule class this handler handles, e.g. "cc_library".
+	Kind() string
+	// Collect returns the target labels rule contributes.
+	Collect(rule *build.Rule) ([]string, error)
+}
+
+// attrStringList returns the string-list-valued attribute named name on
+// rule, or nil if rule has no such attribute.
+func attrStringList(rule *build.Rule, name string) []string {
+	for _, attr := range rule.GetAttribute() {
+		if attr.GetName() == name {
+			return attr.GetStringListValue()
+		}
+	}
+	return nil
+}
+
+// attrString returns the string-valued attribute named name on rule, or ""
+// if rule has no such attribute.
+func attrString(rule *build.Rule, name string) string {
+	for _, attr := range rule.GetAttribute() {
+		if attr.GetName() == name {
+			return attr.GetStringValue()
+		}
+	}
+	return ""
+}
+
+// filegroupHandler handles Bazel's built-in filegroup rule: every label in
+// "srcs" is a file.
+type filegroupHandler struct{}
+
+func (filegroupHandler) Kind() string { return "filegroup" }
+
+func (filegroupHandler) Collect(rule *build.Rule) ([]string, error) {
+	return attrStringList(rule, "srcs"), nil
+}
+
+// ccLibraryHandler handles Bazel's built-in cc_library rule: its files are
+// the union of "srcs", "hdrs" and "textual_hdrs". A cc_library may also
+// declare "strip_include_prefix", which only affects how its headers are
+// quote-included elsewhere in the build and does not change which files
+// belong to the library, so it needs no special handling here.
+type ccLibraryHandler struct{}
+
+func (ccLibraryHandler) Kind() string { return "cc_library" }
+
+func (ccLibraryHandler) Collect(rule *build.Rule) ([]string, error) {
+	var out []string
+	out = append(out, attrStringList(rule, "srcs")...)
+	out = append(out, attrStringList(rule, "hdrs")...)
+	out = append(out, attrStringList(rule, "textual_hdrs")...)
+	return out, nil
+}
+
+// skiaCCLibraryHandler handles skia_cc_library, Skia's Starlark macro that
+// wraps cc_library with extra, exporter-irrelevant attributes (e.g.
+// feature gating). Its files are collected the same way as a plain
+// cc_library's.
+type skiaCCLibraryHandler struct {
+	ccLibraryHandler
+}
+
+func (skiaCCLibraryHandler) Kind() string { return "skia_cc_library" }
+
+// aliasHandler handles Bazel's built-in alias rule by following its
+// "actual" attribute to the real target within the same query result and
+// deferring to that target's own handler.
+type aliasHandler struct {
+	rules    map[string]*build.Rule
+	handlers map[string]RuleHandler
+}
+
+func (aliasHandler) Kind() string { return "alias" }
+
+func (h aliasHandler) Collect(rule *build.Rule) ([]string, error) {
+	actual := attrString(rule, "actual")
+	if actual == "" {
+		return nil, fmt.Errorf("alias %s has no \"actual\" attribute", rule.GetName())
+	}
+	target, ok := h.rules[actual]
+	if !ok {
+		return nil, fmt.Errorf("alias %s: %w", rule.GetName(), newUnknownNameError("target", actual, ruleLabels(h.rules)))
+	}
+	handler, ok := h.handlers[target.GetRuleClass()]
+	if !ok {
+		return nil, fmt.Errorf("alias %s: %w", rule.GetName(), newUnknownNameError("rule class", target.GetRuleClass(), ruleHandlerKinds(h.handlers)))
+	}
+	return handler.Collect(target)
+}
+
+// newRuleHandlers returns the RuleHandler registered for every rule class
+// the exporters understand, keyed by Kind(). rules is the full set of
+// rules resolved from a single query, so the alias handler can follow
+// "actual" within it.
+func newRuleHandlers(rules map[string]*build.Rule) map[string]RuleHandler {
+	handlers := map[string]RuleHandler{}
+	for _, h := range []RuleHandler{
+		filegroupHandler{},
+		ccLibraryHandler{},
+		skiaCCLibraryHandler{},
+	} {
+		handlers[h.Kind()] = h
+	}
+	handlers["alias"] = aliasHandler{rules: rules, handlers: handlers}
+	return handlers
+}
+
+// ruleHandlerKinds returns the sorted set of rule classes handlers knows
+// how to collect, used as the candidate corpus for "did you mean"
+// diagnostics.
+func ruleHandlerKinds(handlers map[string]RuleHandler) []string {
+	kinds := make([]string, 0, len(handlers))
+	for k := range handlers {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// collectRuleTargets looks up ruleLabel in rules, dispatches it to the
+// RuleHandler registered for its rule class, and returns the target labels
+// it contributes.
+func collectRuleTargets(rules map[string]*build.Rule, handlers map[string]RuleHandler, ruleLabel string) ([]string, error) {
+	rule, ok := rules[ruleLabel]
+	if !ok {
+		return nil, newUnknownNameError("target", ruleLabel, ruleLabels(rules))
+	}
+	handler, ok := handlers[rule.GetRuleClass()]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", ruleLabel, newUnknownNameError("rule class", rule.GetRuleClass(), ruleHandlerKinds(handlers)))
+	}
+	return handler.Collect(rule)
+}
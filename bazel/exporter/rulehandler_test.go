@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"google.golang.org/protobuf/proto"
+)
+
+func createTestAttrRule(label, ruleClass string, attrs map[string][]string) *build.Rule {
+	attrType := build.Attribute_STRING_LIST
+	rule := &build.Rule{Name: proto.String(label), RuleClass: proto.String(ruleClass)}
+	for name, values := range attrs {
+		rule.Attribute = append(rule.Attribute, &build.Attribute{
+			Name:            proto.String(name),
+			Type:            &attrType,
+			StringListValue: values,
+		})
+	}
+	return rule
+}
+
+func TestFilegroupHandler_Collect_ReturnsSrcs(t *testing.T) {
+	rule := createTestAttrRule("//src/core:core_srcs", "filegroup", map[string][]string{
+		"srcs": {"//src/core:SkA.cpp", "//src/core:SkB.cpp"},
+	})
+	got, err := filegroupHandler{}.Collect(rule)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"//src/core:SkA.cpp", "//src/core:SkB.cpp"}, got)
+}
+
+func TestCCLibraryHandler_Collect_ReturnsSrcsHdrsAndTextualHdrs(t *testing.T) {
+	rule := createTestAttrRule("//src/core:core", "cc_library", map[string][]string{
+		"srcs":         {"//src/core:SkA.cpp"},
+		"hdrs":         {"//include/core:SkA.h"},
+		"textual_hdrs": {"//src/core:SkA_impl.inc"},
+	})
+	got, err := ccLibraryHandler{}.Collect(rule)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"//src/core:SkA.cpp", "//include/core:SkA.h", "//src/core:SkA_impl.inc"}, got)
+}
+
+func TestSkiaCCLibraryHandler_Collect_SameAsCCLibrary(t *testing.T) {
+	rule := createTestAttrRule("//src/core:core", "skia_cc_library", map[string][]string{
+		"srcs": {"//src/core:SkA.cpp"},
+		"hdrs": {"//include/core:SkA.h"},
+	})
+	got, err := skiaCCLibraryHandler{}.Collect(rule)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"//src/core:SkA.cpp", "//include/core:SkA.h"}, got)
+}
+
+func TestAliasHandler_Collect_FollowsActualWithinQueryResult(t *testing.T) {
+	actual := createTestAttrRule("//src/core:core", "cc_library", map[string][]string{
+		"srcs": {"//src/core:SkA.cpp"},
+	})
+	rules := map[string]*build.Rule{"//src/core:core": actual}
+	handlers := newRuleHandlers(rules)
+
+	alias := createTestAttrRule("//src/core:core_alias", "alias", nil)
+	alias.Attribute = append(alias.Attribute, &build.Attribute{
+		Name:        proto.String("actual"),
+		StringValue: proto.String("//src/core:core"),
+	})
+
+	got, err := handlers["alias"].Collect(alias)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"//src/core:SkA.cpp"}, got)
+}
+
+func TestAliasHandler_Collect_UnknownActual_ReturnsSuggestionError(t *testing.T) {
+	rules := map[string]*build.Rule{
+		"//src/core:core": createTestAttrRule("//src/core:core", "cc_library", nil),
+	}
+	handlers := newRuleHandlers(rules)
+
+	alias := createTestAttrRule("//src/core:core_alias", "alias", nil)
+	alias.Attribute = append(alias.Attribute, &build.Attribute{
+		Name:        proto.String("actual"),
+		StringValue: proto.String("//src/core:cor"),
+	})
+
+	_, err := handlers["alias"].Collect(alias)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean")
+}
+
+func TestCollectRuleTargets_UnregisteredRuleClass_ReturnsSuggestionError(t *testing.T) {
+	rules := map[string]*build.Rule{
+		"//src/core:core": createTestAttrRule("//src/core:core", "genrule", nil),
+	}
+	handlers := newRuleHandlers(rules)
+
+	_, err := collectRuleTargets(rules, handlers, "//src/core:core")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "genrule")
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces"
+)
+
+// readDelimitedTarget reads one length-delimited build.Target message from
+// r, in the form `bazel query --output=streamed_proto` writes them: a
+// varint byte count followed by that many bytes of marshalled Target
+// proto. It returns io.EOF, unwrapped, once r is exhausted between
+// messages.
+func readDelimitedTarget(r *bufio.Reader) (*build.Target, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("reading delimited target: %w", err)
+	}
+	t := &build.Target{}
+	if err := proto.Unmarshal(buf, t); err != nil {
+		return nil, fmt.Errorf("unmarshalling delimited target: %w", err)
+	}
+	return t, nil
+}
+
+// pendingGNIDesc tracks, for a single GNIExportDesc, the set of rule
+// labels exportStreaming is still waiting to see before that .gni file can
+// be built and written. need starts out holding exactly the labels listed
+// literally in desc's GNIFileListExportDescs, but grows as alias rules
+// arrive: seeing an alias satisfies its own label but adds its "actual"
+// target in its place, so a .gni file isn't considered ready until every
+// alias in it has been followed all the way to a non-alias rule.
+type pendingGNIDesc struct {
+	desc *GNIExportDesc
+	need map[string]bool
+}
+
+// pendingGNIDescs computes the initial, unsatisfied pendingGNIDesc for
+// every .gni file e maintains.
+func (e *GNIExporter) pendingGNIDescs() []*pendingGNIDesc {
+	pending := make([]*pendingGNIDesc, 0, len(e.params.ExportDescs))
+	for i := range e.params.ExportDescs {
+		desc := &e.params.ExportDescs[i]
+		need := map[string]bool{}
+		for _, v := range desc.Vars {
+			for _, r := range v.Rules {
+				need[r] = true
+			}
+		}
+		pending = append(pending, &pendingGNIDesc{desc: desc, need: need})
+	}
+	return pending
+}
+
+// flushReadyGNIDescs builds and writes every pendingGNIDesc in pending
+// whose rules have all arrived, using the rules seen so far. It returns
+// the pendingGNIDesc entries still waiting on at least one rule.
+func (e *GNIExporter) flushReadyGNIDescs(pending []*pendingGNIDesc, rules map[string]*build.Rule) ([]*pendingGNIDesc, error) {
+	remaining := pending[:0]
+	for _, pd := range pending {
+		if len(pd.need) > 0 {
+			remaining = append(remaining, pd)
+			continue
+		}
+		file, err := e.buildGNIFile(*pd.desc, rules)
+		if err != nil {
+			return nil, fmt.Errorf("building %s: %w", pd.desc.GNI, err)
+		}
+		w, err := e.fs.OpenFile(e.workspaceToAbsPath(pd.desc.GNI))
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", pd.desc.GNI, err)
+		}
+		if _, err := w.Write(file.Format()); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", pd.desc.GNI, err)
+		}
+	}
+	return remaining, nil
+}
+
+// resolveAlias updates pd.need to account for rule having just arrived: if
+// rule is an alias, its own label is satisfied but its "actual" target
+// becomes newly needed in its place, unless that target has already
+// arrived too, in which case resolveAlias follows the chain immediately.
+// `bazel query --output=streamed_proto` makes no promise that an alias and
+// the target it points to arrive in any particular order, so this must
+// handle both.
+func resolveAlias(pd *pendingGNIDesc, rule *build.Rule, rules map[string]*build.Rule) {
+	if rule.GetRuleClass() != "alias" {
+		return
+	}
+	actual := attrString(rule, "actual")
+	if actual == "" {
+		return
+	}
+	if target, ok := rules[actual]; ok {
+		resolveAlias(pd, target, rules)
+		return
+	}
+	pd.need[actual] = true
+}
+
+// exportStreaming is the streamed_proto counterpart to Export: it reads
+// Target messages one at a time instead of unmarshalling an entire
+// QueryResult, and writes each .gni file as soon as every rule it
+// references has arrived, so the full set of rules is never held in
+// memory at once alongside the full set of targets.
+func (e *GNIExporter) exportStreaming(qcmd interfaces.StreamingQueryCommand) error {
+	rd, err := qcmd.ReadStreaming(e.queryExpression())
+	if err != nil {
+		return fmt.Errorf("querying rules: %w", err)
+	}
+
+	rules := map[string]*build.Rule{}
+	pending := e.pendingGNIDescs()
+	br := bufio.NewReader(rd)
+	for {
+		t, err := readDelimitedTarget(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("querying rules: %w", err)
+		}
+		if t.GetType() != build.Target_RULE {
+			continue
+		}
+		rule := t.GetRule()
+		rules[rule.GetName()] = rule
+		for _, pd := range pending {
+			if pd.need[rule.GetName()] {
+				delete(pd.need, rule.GetName())
+				resolveAlias(pd, rule, rules)
+			}
+		}
+
+		pending, err = e.flushReadyGNIDescs(pending, rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(pending) > 0 {
+		missing := make([]string, len(pending))
+		for i, pd := range pending {
+			missing[i] = pd.desc.GNI
+		}
+		return fmt.Errorf("streamed query result ended before %v could be fully resolved", missing)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/skia/bazel/exporter/build_proto/build"
+	"go.skia.org/skia/bazel/exporter/interfaces/mocks"
+	"google.golang.org/protobuf/proto"
+)
+
+// marshalDelimited appends a varint-length-prefixed, marshalled copy of
+// each target to a stream in the form `bazel query --output=streamed_proto`
+// emits.
+func marshalDelimited(t *testing.T, targets []*build.Target) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, target := range targets {
+		data, err := proto.Marshal(target)
+		require.NoError(t, err)
+		var sizeBuf [binary.MaxVarintLen64]byte
+		sizeLen := binary.PutUvarint(sizeBuf[:], uint64(len(data)))
+		buf.Write(sizeBuf[:sizeLen])
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+// streamingOnlyMock adapts a *mocks.StreamingQueryCommand (ReadStreaming
+// only) to interfaces.QueryCommand so it can be passed to Export: Export
+// always prefers ReadStreaming when a qcmd implements it, so Read itself is
+// never expected to be called, and fails the test if it is.
+type streamingOnlyMock struct {
+	t *testing.T
+	*mocks.StreamingQueryCommand
+}
+
+func (q streamingOnlyMock) Read(query string) ([]byte, error) {
+	q.t.Fatal("unexpected buffered Read on a streaming-only query command")
+	return nil, nil
+}
+
+func TestGNIExporterExport_StreamingQueryCommand_Success(t *testing.T) {
+	qr := createCoreSourcesQueryResult()
+	streamed := marshalDelimited(t, qr.GetTarget())
+
+	fs := mocks.NewFileSystem(t)
+	var contents bytes.Buffer
+	fs.On("OpenFile", mock.Anything).Once().Return(&contents, nil).Once()
+	e := NewGNIExporter(testExporterParams, fs)
+	sqcmd := mocks.NewStreamingQueryCommand(t)
+	sqcmd.On("ReadStreaming", mock.Anything).Return(bytes.NewReader(streamed), nil).Once()
+	err := e.Export(streamingOnlyMock{t: t, StreamingQueryCommand: sqcmd})
+	require.NoError(t, err)
+
+	assert.Equal(t, publicSrcsExpectedGNI, contents.String())
+}
+
+func TestGNIExporterExport_StreamingQueryCommandMissingRule_ReturnsError(t *testing.T) {
+	qr := createCoreSourcesQueryResult()
+	// Drop the second rule so skia_core_sources can never become ready.
+	streamed := marshalDelimited(t, qr.GetTarget()[:1])
+
+	fs := mocks.NewFileSystem(t)
+	e := NewGNIExporter(testExporterParams, fs)
+	sqcmd := mocks.NewStreamingQueryCommand(t)
+	sqcmd.On("ReadStreaming", mock.Anything).Return(bytes.NewReader(streamed), nil).Once()
+	err := e.Export(streamingOnlyMock{t: t, StreamingQueryCommand: sqcmd})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gn/core.gni")
+}
+
+func TestGNIExporterExport_StreamingQueryCommandAliasBeforeTarget_Success(t *testing.T) {
+	aliasParams := GNIExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []GNIFileListExportDesc{
+				{Var: "skia_core_sources", Rules: []string{"//src/core:core_srcs_alias"}}},
+			},
+		},
+	}
+
+	alias := createTestAttrRule("//src/core:core_srcs_alias", "alias", nil)
+	alias.Attribute = append(alias.Attribute, &build.Attribute{
+		Name:        proto.String("actual"),
+		StringValue: proto.String("//src/core:core_srcs"),
+	})
+	target := createTestBuildRule("//src/core:core_srcs", "filegroup",
+		"/path/to/workspace/src/core/BUILD.bazel:376:20", []string{"//src/core:SkAAClip.cpp"})
+
+	// Stream the alias before the rule it points to: exportStreaming must
+	// not treat skia_core_sources as ready until core_srcs has also
+	// arrived, since `bazel query --output=streamed_proto` gives no
+	// ordering guarantee between the two.
+	ruleDesc := build.Target_RULE
+	streamed := marshalDelimited(t, []*build.Target{
+		{Rule: alias, Type: &ruleDesc},
+		{Rule: target, Type: &ruleDesc},
+	})
+
+	fs := mocks.NewFileSystem(t)
+	var contents bytes.Buffer
+	fs.On("OpenFile", mock.Anything).Once().Return(&contents, nil).Once()
+	e := NewGNIExporter(aliasParams, fs)
+	sqcmd := mocks.NewStreamingQueryCommand(t)
+	sqcmd.On("ReadStreaming", mock.Anything).Return(bytes.NewReader(streamed), nil).Once()
+	err := e.Export(streamingOnlyMock{t: t, StreamingQueryCommand: sqcmd})
+	require.NoError(t, err)
+
+	assert.Contains(t, contents.String(), "SkAAClip.cpp")
+}
+
+// newSyntheticStreamedQueryResult builds a streamed_proto payload of n
+// filegroup rules, each with a handful of sources, for use in benchmarks.
+func newSyntheticStreamedQueryResult(n int) ([]byte, []string) {
+	ruleDesc := build.Target_RULE
+	var buf bytes.Buffer
+	rules := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		label := fmt.Sprintf("//src/synthetic:rule_%d", i)
+		rules = append(rules, label)
+		srcs := []string{
+			fmt.Sprintf("//src/synthetic:File%d_a.cpp", i),
+			fmt.Sprintf("//src/synthetic:File%d_b.cpp", i),
+		}
+		attrType := build.Attribute_STRING_LIST
+		rule := &build.Rule{
+			Name:      proto.String(label),
+			RuleClass: proto.String("filegroup"),
+			Attribute: []*build.Attribute{{Name: proto.String("srcs"), Type: &attrType, StringListValue: srcs}},
+		}
+		target := &build.Target{Rule: rule, Type: &ruleDesc}
+		data, _ := proto.Marshal(target)
+		var sizeBuf [binary.MaxVarintLen64]byte
+		sizeLen := binary.PutUvarint(sizeBuf[:], uint64(len(data)))
+		buf.Write(sizeBuf[:sizeLen])
+		buf.Write(data)
+	}
+	return buf.Bytes(), rules
+}
+
+// BenchmarkGNIExporterExport_Buffered exercises the original, whole-payload
+// QueryCommand.Read path against a synthetic 50k-rule QueryResult.
+func BenchmarkGNIExporterExport_Buffered(b *testing.B) {
+	streamed, labels := newSyntheticStreamedQueryResult(50000)
+	qr := &build.QueryResult{}
+	br := bufio.NewReader(bytes.NewReader(streamed))
+	for {
+		t, err := readDelimitedTarget(br)
+		if err == io.EOF {
+			break
+		}
+		qr.Target = append(qr.Target, t)
+	}
+	data, _ := proto.Marshal(qr)
+
+	params := GNIExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/synthetic.gni", Vars: []GNIFileListExportDesc{{Var: "skia_synthetic_sources", Rules: labels}}},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fs := discardFileSystem{}
+		e := NewGNIExporter(params, fs)
+		qcmd := bufferedBytesQueryCommand{data: data}
+		if err := e.Export(qcmd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGNIExporterExport_Streaming exercises exportStreaming against
+// the same synthetic 50k-rule payload, processing targets incrementally
+// instead of unmarshalling one 50k-entry QueryResult.
+func BenchmarkGNIExporterExport_Streaming(b *testing.B) {
+	streamed, labels := newSyntheticStreamedQueryResult(50000)
+
+	params := GNIExporterParams{
+		WorkspaceDir: "/path/to/workspace",
+		ExportDescs: []GNIExportDesc{
+			{GNI: "gn/synthetic.gni", Vars: []GNIFileListExportDesc{{Var: "skia_synthetic_sources", Rules: labels}}},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fs := discardFileSystem{}
+		e := NewGNIExporter(params, fs)
+		qcmd := streamedBytesQueryCommand{data: streamed}
+		if err := e.Export(qcmd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// discardFileSystem is an interfaces.FileSystem that throws away
+// everything written to it, for use in benchmarks that only care about the
+// exporter's own CPU and memory cost.
+type discardFileSystem struct{}
+
+func (discardFileSystem) OpenFile(path string) (io.Writer, error) { return io.Discard, nil }
+func (discardFileSystem) ReadFile(path string) ([]byte, error)    { return nil, nil }
+
+// bufferedBytesQueryCommand is an interfaces.QueryCommand returning a
+// fixed, already-marshalled QueryResult.
+type bufferedBytesQueryCommand struct{ data []byte }
+
+func (q bufferedBytesQueryCommand) Read(query string) ([]byte, error) { return q.data, nil }
+
+// streamedBytesQueryCommand is an interfaces.StreamingQueryCommand
+// returning a fixed, already-marshalled streamed_proto payload.
+type streamedBytesQueryCommand struct{ data []byte }
+
+func (q streamedBytesQueryCommand) ReadStreaming(query string) (io.Reader, error) {
+	return bytes.NewReader(q.data), nil
+}
+
+// Read exists only so streamedBytesQueryCommand satisfies
+// interfaces.QueryCommand; Export always prefers ReadStreaming when both
+// are available, so this is never called.
+func (q streamedBytesQueryCommand) Read(query string) ([]byte, error) {
+	return nil, fmt.Errorf("unexpected buffered Read on a streaming-only query command")
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the number of nearest-match candidates reported in a
+// "did you mean" diagnostic.
+const maxSuggestions = 3
+
+// levenshteinDistance computes the classic edit distance between a and b,
+// using a cost of 1 for each insertion, deletion and substitution.
+func levenshteinDistance(a, b string) int {
+	la, lb := len(a), len(b)
+	if la == 0 {
+		return lb
+	}
+	if lb == 0 {
+		return la
+	}
+
+	prev := make([]int, lb+1)
+	curr := make([]int, lb+1)
+	for j := 0; j <= lb; j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= la; i++ {
+		curr[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, minInt(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev, curr = curr, prev
+	}
+	return prev[lb]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// suggestionMaxDistance returns the largest edit distance worth suggesting
+// for a name of the given length: at least 2, growing slowly with longer
+// names so that two labels sharing a long common prefix (e.g. a package
+// path) but differing by more than a typo in their final component aren't
+// treated as plausible matches.
+func suggestionMaxDistance(nameLen int) int {
+	return maxInt(2, nameLen/6)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// suggestNames returns up to maxSuggestions entries from corpus that are
+// plausible typo-corrections for name, nearest match first. Candidates
+// whose length differs from name by more than 3, or whose edit distance
+// exceeds suggestionMaxDistance(len(name)), are not considered.
+func suggestNames(name string, corpus []string) []string {
+	type candidate struct {
+		name string
+		dist int
+	}
+
+	maxDist := suggestionMaxDistance(len(name))
+	var candidates []candidate
+	for _, c := range corpus {
+		if c == name {
+			continue
+		}
+		if absInt(len(c)-len(name)) > 3 {
+			continue
+		}
+		if d := levenshteinDistance(name, c); d <= maxDist {
+			candidates = append(candidates, candidate{name: c, dist: d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	if len(candidates) > maxSuggestions {
+		candidates = candidates[:maxSuggestions]
+	}
+
+	out := make([]string, len(candidates))
+	for i, c := range candidates {
+		out[i] = c.name
+	}
+	return out
+}
+
+// newUnknownNameError formats a "did you mean" diagnostic for name, which
+// wasn't found among corpus.
+func newUnknownNameError(kind, name string, corpus []string) error {
+	suggestions := suggestNames(name, corpus)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("unknown %s `%s`", kind, name)
+	}
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = fmt.Sprintf("`%s`", s)
+	}
+	return fmt.Errorf("unknown %s `%s` — did you mean %s?", kind, name, strings.Join(quoted, " or "))
+}
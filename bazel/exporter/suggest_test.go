@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshteinDistance_KnownPairs_ReturnsExpectedDistance(t *testing.T) {
+	test := func(name, a, b string, expected int) {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, levenshteinDistance(a, b))
+			assert.Equal(t, expected, levenshteinDistance(b, a))
+		})
+	}
+
+	test("Identical", "private_hdrs", "private_hdrs", 0)
+	test("OneSubstitution", "private_hdrz", "private_hdrs", 1)
+	test("OneInsertion", "private_hdr", "private_hdrs", 1)
+	test("EmptyString", "", "abc", 3)
+}
+
+func TestSuggestNames_CloseMatchPresent_ReturnsNearestFirst(t *testing.T) {
+	corpus := []string{
+		"//src/opts:private_hdrs",
+		"//src/opts:private_headers",
+		"//src/core:core_srcs",
+	}
+	got := suggestNames("//src/opts:private_hdrz", corpus)
+	assert.Equal(t, []string{"//src/opts:private_hdrs"}, got)
+}
+
+func TestSuggestNames_NoCandidateWithinThreshold_ReturnsEmpty(t *testing.T) {
+	corpus := []string{"//src/core:core_srcs"}
+	got := suggestNames("//completely/unrelated:target", corpus)
+	assert.Empty(t, got)
+}
+
+func TestSuggestNames_ManyCloseMatches_ReturnsTopThree(t *testing.T) {
+	corpus := []string{"cat", "bat", "hat", "mat", "rat"}
+	got := suggestNames("cot", corpus)
+	assert.Len(t, got, maxSuggestions)
+}
+
+func TestNewUnknownNameError_WithSuggestion_MentionsIt(t *testing.T) {
+	err := newUnknownNameError("target", "//src/opts:private_hdrz", []string{"//src/opts:private_hdrs"})
+	assert.Contains(t, err.Error(), "private_hdrz")
+	assert.Contains(t, err.Error(), "private_hdrs")
+}
+
+func TestNewUnknownNameError_NoSuggestion_OmitsDidYouMean(t *testing.T) {
+	err := newUnknownNameError("target", "//nope:nope", nil)
+	assert.NotContains(t, err.Error(), "did you mean")
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import "go.skia.org/skia/bazel/exporter"
+
+// gniExporterParams returns the .gni files and variables this tool keeps
+// in sync with BUILD.bazel, rooted at workspaceDir.
+func gniExporterParams(workspaceDir string) exporter.GNIExporterParams {
+	return exporter.GNIExporterParams{
+		WorkspaceDir: workspaceDir,
+		ExportDescs: []exporter.GNIExportDesc{
+			{GNI: "gn/core.gni", Vars: []exporter.GNIFileListExportDesc{
+				{Var: "skia_core_sources", Rules: []string{
+					"//src/core:core_srcs",
+					"//src/opts:private_hdrs",
+				}},
+			}},
+		},
+	}
+}
+
+// cmakeExporterParams returns the CMakeLists.txt files and libraries this
+// tool keeps in sync with BUILD.bazel, rooted at workspaceDir.
+func cmakeExporterParams(workspaceDir string) exporter.CMakeExporterParams {
+	return exporter.CMakeExporterParams{
+		WorkspaceDir: workspaceDir,
+		ExportDescs: []exporter.CMakeExportDesc{
+			{CMakeLists: "CMakeLists.txt", Libraries: []exporter.CMakeLibraryExportDesc{
+				{
+					Name:        "skia_core",
+					Rules:       []string{"//src/core:core_srcs"},
+					IncludeDirs: []string{"include"},
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// exporter_tool regenerates the build files Skia ships alongside its
+// Bazel BUILD.bazel files (GN's .gni lists, CMakeLists.txt, ...) from the
+// output of a `bazel cquery`. See README.md for more information.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.skia.org/skia/bazel/exporter"
+)
+
+var (
+	workspaceDir = flag.String("workspace", ".", "Path to the root of the Bazel workspace.")
+	formats      = flag.String("format", "gni", "Comma-separated list of exporters to run, e.g. \"gni,cmake\".")
+	checkOnly    = flag.Bool("check", false, "Report out-of-date files instead of regenerating them; exit non-zero if any are stale. Only supported by --format=gni.")
+)
+
+func main() {
+	flag.Parse()
+	absWorkspace, err := filepath.Abs(*workspaceDir)
+	if err != nil {
+		log.Fatalf("resolving --workspace: %s", err)
+	}
+
+	fs := osFileSystem{}
+	qcmd := bazelQueryCommand{workspaceDir: absWorkspace}
+
+	for _, format := range strings.Split(*formats, ",") {
+		switch strings.TrimSpace(format) {
+		case "gni":
+			e := exporter.NewGNIExporter(gniExporterParams(absWorkspace), fs)
+			if *checkOnly {
+				numOutOfDate, err := e.CheckCurrent(qcmd, os.Stderr)
+				if err != nil {
+					log.Fatalf("checking gni exports: %s", err)
+				}
+				if numOutOfDate > 0 {
+					os.Exit(1)
+				}
+				continue
+			}
+			if err := e.Export(qcmd); err != nil {
+				log.Fatalf("exporting gni: %s", err)
+			}
+		case "cmake":
+			e := exporter.NewCMakeExporter(cmakeExporterParams(absWorkspace), fs)
+			if err := e.Export(qcmd); err != nil {
+				log.Fatalf("exporting cmake: %s", err)
+			}
+		default:
+			log.Fatalf("unknown --format %q: must be one of \"gni\", \"cmake\"", format)
+		}
+	}
+}
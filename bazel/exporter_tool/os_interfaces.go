@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// osFileSystem is the real, on-disk implementation of interfaces.FileSystem
+// used outside of tests.
+type osFileSystem struct{}
+
+// OpenFile implements interfaces.FileSystem.
+func (osFileSystem) OpenFile(path string) (io.Writer, error) {
+	return os.Create(path)
+}
+
+// ReadFile implements interfaces.FileSystem.
+func (osFileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// bazelQueryCommand is the real implementation of interfaces.QueryCommand
+// (shelling out to `bazel cquery`) and interfaces.StreamingQueryCommand
+// (shelling out to `bazel query`).
+type bazelQueryCommand struct {
+	workspaceDir string
+}
+
+// Read implements interfaces.QueryCommand by running `bazel cquery
+// --output=proto` for query inside workspaceDir.
+func (b bazelQueryCommand) Read(query string) ([]byte, error) {
+	cmd := exec.Command("bazel", "cquery", "--output=proto", query)
+	cmd.Dir = b.workspaceDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running bazel cquery for %q: %w", query, err)
+	}
+	return out, nil
+}
+
+// ReadStreaming implements interfaces.StreamingQueryCommand by running
+// `bazel query --output=streamed_proto` for query inside workspaceDir and
+// streaming its stdout straight back, so a large QueryResult never has to
+// be buffered in full.
+func (b bazelQueryCommand) ReadStreaming(query string) (io.Reader, error) {
+	cmd := exec.Command("bazel", "query", "--output=streamed_proto", query)
+	cmd.Dir = b.workspaceDir
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("piping bazel query stdout for %q: %w", query, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting bazel query for %q: %w", query, err)
+	}
+	return stdout, nil
+}